@@ -0,0 +1,61 @@
+package cherry
+
+import "net/http"
+
+// A RequestInterceptor runs against a built *http.Request before it is
+// sent, letting callers attach authentication or other request-scoped
+// behavior without touching the transport.
+type RequestInterceptor interface {
+	Intercept(req *http.Request) error
+}
+
+// RequestInterceptorFunc adapts a function to a RequestInterceptor.
+type RequestInterceptorFunc func(req *http.Request) error
+
+// Intercept calls fn(req).
+func (fn RequestInterceptorFunc) Intercept(req *http.Request) error {
+	return fn(req)
+}
+
+// BearerAuth returns a RequestInterceptor that sets the Authorization
+// header to a bearer token obtained from tokenSource on every request,
+// letting callers plug in refresh-token providers and the like.
+func BearerAuth(tokenSource func() (string, error)) RequestInterceptor {
+	return RequestInterceptorFunc(func(req *http.Request) error {
+		token, err := tokenSource()
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// BasicAuth returns a RequestInterceptor that sets HTTP Basic
+// authentication credentials on every request.
+func BasicAuth(user, pass string) RequestInterceptor {
+	return RequestInterceptorFunc(func(req *http.Request) error {
+		req.SetBasicAuth(user, pass)
+		return nil
+	})
+}
+
+// APIKeyHeader returns a RequestInterceptor that sets an API key as the
+// header named name.
+func APIKeyHeader(name, value string) RequestInterceptor {
+	return RequestInterceptorFunc(func(req *http.Request) error {
+		req.Header.Set(name, value)
+		return nil
+	})
+}
+
+// APIKeyQuery returns a RequestInterceptor that sets an API key as the
+// query parameter named name.
+func APIKeyQuery(name, value string) RequestInterceptor {
+	return RequestInterceptorFunc(func(req *http.Request) error {
+		q := req.URL.Query()
+		q.Set(name, value)
+		req.URL.RawQuery = q.Encode()
+		return nil
+	})
+}
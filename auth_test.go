@@ -0,0 +1,93 @@
+package cherry_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tetsuo/cherry"
+)
+
+func TestRequestInterceptors(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		interceptor cherry.RequestInterceptor
+		assert      func(*testing.T, *http.Request)
+	}{
+		{
+			desc:        "BearerAuth",
+			interceptor: cherry.BearerAuth(func() (string, error) { return "tok123", nil }),
+			assert: func(t *testing.T, req *http.Request) {
+				assert.Equal(t, "Bearer tok123", req.Header.Get("Authorization"))
+			},
+		},
+		{
+			desc:        "BasicAuth",
+			interceptor: cherry.BasicAuth("alice", "secret"),
+			assert: func(t *testing.T, req *http.Request) {
+				user, pass, ok := req.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "alice", user)
+				assert.Equal(t, "secret", pass)
+			},
+		},
+		{
+			desc:        "APIKeyHeader",
+			interceptor: cherry.APIKeyHeader("X-Api-Key", "abc"),
+			assert: func(t *testing.T, req *http.Request) {
+				assert.Equal(t, "abc", req.Header.Get("X-Api-Key"))
+			},
+		},
+		{
+			desc:        "APIKeyQuery",
+			interceptor: cherry.APIKeyQuery("api_key", "abc"),
+			assert: func(t *testing.T, req *http.Request) {
+				assert.Equal(t, "abc", req.URL.Query().Get("api_key"))
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			mux := http.NewServeMux()
+			var captured *http.Request
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				captured = r
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":"ab"}`))
+			})
+
+			resp, e, err := cherry.Send(&testClient{
+				mux: mux,
+			}, &cherry.Request[entry]{
+				Method:       "GET",
+				URL:          "/",
+				Interceptors: []cherry.RequestInterceptor{tC.interceptor},
+			})
+
+			assert.NoError(t, err)
+			assert.NotNil(t, e)
+			assert.Equal(t, 200, resp.StatusCode)
+			tC.assert(t, captured)
+		})
+	}
+}
+
+func TestRequestInterceptorError(t *testing.T) {
+	mux := http.NewServeMux()
+	boom := errors.New("boom")
+
+	_, e, err := cherry.Send(&testClient{
+		mux: mux,
+	}, &cherry.Request[entry]{
+		Method: "GET",
+		URL:    "/",
+		Interceptors: []cherry.RequestInterceptor{
+			cherry.BearerAuth(func() (string, error) { return "", boom }),
+		},
+	})
+
+	assert.Nil(t, e)
+	assert.ErrorIs(t, err, cherry.ErrBadRequest)
+	assert.ErrorIs(t, err, boom)
+}
@@ -3,12 +3,12 @@ package cherry
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 )
@@ -30,14 +30,41 @@ var (
 )
 
 func toRequestWithContext[A any](ctx context.Context, r *Request[A]) (req *http.Request, err error) {
+	contentType := r.ContentType
+	if contentType == "" {
+		contentType = MediaTypeJSON
+	}
 	var body io.Reader
 	if r.Body != nil && !(r.Method == "GET" || r.Method == "OPTIONS") {
-		var buf []byte
-		buf, err = json.Marshal(r.Body)
-		if err != nil {
-			return
+		if raw, ok := r.Body.(rawBody); ok {
+			// Body was built by Stream or Multipart: send it as-is, without
+			// going through a Producer, so it never has to be buffered in
+			// memory.
+			body = raw.Reader
+			defer func() {
+				// If the request is never dispatched, nobody will ever read
+				// or close this body. For a Multipart pipe in particular,
+				// that leaves the writer goroutine blocked on pw.Write
+				// forever, so close it here to unblock it with
+				// io.ErrClosedPipe.
+				if err != nil {
+					if closer, ok := raw.Reader.(io.Closer); ok {
+						closer.Close()
+					}
+				}
+			}()
+		} else {
+			producer, ok := DefaultMediaTypeRegistry.ProducerFor(contentType)
+			if !ok {
+				err = fmt.Errorf("cherry: no producer registered for %q", contentType)
+				return
+			}
+			var buf bytes.Buffer
+			if err = producer.Produce(&buf, r.Body); err != nil {
+				return
+			}
+			body = &buf
 		}
-		body = bytes.NewBuffer(buf)
 	}
 	req, err = http.NewRequestWithContext(ctx, r.Method, r.URL, body)
 	if err != nil {
@@ -46,14 +73,34 @@ func toRequestWithContext[A any](ctx context.Context, r *Request[A]) (req *http.
 	for key, value := range r.Headers {
 		req.Header.Add(key, value)
 	}
-	req.Header.Set("Accept", "application/json")
+	accept := r.Accept
+	if accept == "" {
+		accept = MediaTypeJSON
+	}
+	req.Header.Set("Accept", accept)
 	req.Header.Set("User-Agent", "cherry/1")
 	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
 	}
 	return
 }
 
+// rewindRawBody seeks a rawBody built by Stream or Multipart back to its
+// start before a retry attempt. It errors if the body isn't an io.Seeker,
+// since such a body can only be sent once.
+func rewindRawBody[A any](r *Request[A]) error {
+	raw, ok := r.Body.(rawBody)
+	if !ok {
+		return nil
+	}
+	seeker, ok := raw.Reader.(io.Seeker)
+	if !ok {
+		return fmt.Errorf("cherry: cannot retry a request whose body is a non-seekable stream")
+	}
+	_, err := seeker.Seek(0, io.SeekStart)
+	return err
+}
+
 // A Client manages the HTTP connection.
 type Client interface {
 	// Do sends an HTTP request and returns an HTTP response, following policy
@@ -69,8 +116,39 @@ func Send[A any](c Client, r *Request[A]) (resp *http.Response, a *A, e error) {
 
 // SendWithContext creates and sends a new context-aware http.Request, returning
 // an HTTP response and a pointer to a value of type A along with an error if
-// any encountered.
+// any encountered. If r has a Retry policy attached, failed attempts are
+// retried according to it.
 func SendWithContext[A any](ctx context.Context, c Client, r *Request[A]) (resp *http.Response, a *A, e error) {
+	return SendWithOptions(ctx, c, r, r.Retry)
+}
+
+// SendWithOptions is SendWithContext with an explicit retry policy, which
+// overrides any policy attached to r.
+func SendWithOptions[A any](ctx context.Context, c Client, r *Request[A], policy *RetryPolicy) (resp *http.Response, a *A, e error) {
+	if policy == nil || policy.MaxAttempts <= 1 {
+		return sendOnce(ctx, c, r)
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if err := rewindRawBody(r); err != nil {
+				resp, a, e = nil, nil, fmt.Errorf("%w: %w", ErrBadRequest, err)
+				return
+			}
+		}
+		resp, a, e = sendOnce(ctx, c, r)
+		if e == nil || attempt == policy.MaxAttempts-1 || !policy.shouldRetry(resp, e) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			e = ctx.Err()
+			return
+		case <-time.After(policy.delay(resp, attempt)):
+		}
+	}
+}
+
+func sendOnce[A any](ctx context.Context, c Client, r *Request[A]) (resp *http.Response, a *A, e error) {
 	var (
 		req *http.Request
 		err error
@@ -79,6 +157,18 @@ func SendWithContext[A any](ctx context.Context, c Client, r *Request[A]) (resp
 		e = fmt.Errorf("%w: %w", ErrBadRequest, err)
 		return
 	}
+	for _, interceptor := range r.Interceptors {
+		if err = interceptor.Intercept(req); err != nil {
+			// The request is abandoned here and will never reach c.Do, so
+			// close its body ourselves: for a Stream/Multipart body, that's
+			// what unblocks a writer goroutine stuck on a full pipe.
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			e = fmt.Errorf("%w: %w", ErrBadRequest, err)
+			return
+		}
+	}
 	if resp, err = c.Do(req); err != nil {
 		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
 			e = ErrTimeout
@@ -89,15 +179,29 @@ func SendWithContext[A any](ctx context.Context, c Client, r *Request[A]) (resp
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if resp.StatusCode == 404 {
-			e = ErrBadURL
-			return
+		e = newHTTPError(resp)
+		return
+	}
+	if r.ResponseWriter != nil {
+		if _, err = io.Copy(r.ResponseWriter, resp.Body); err != nil {
+			e = err
 		}
-		e = ErrBadStatus
+		return
+	}
+	mediaType := resp.Header.Get("Content-Type")
+	if mediaType == "" {
+		mediaType = r.Accept
+	}
+	if mediaType == "" {
+		mediaType = MediaTypeJSON
+	}
+	consumer, ok := DefaultMediaTypeRegistry.ConsumerFor(mediaType)
+	if !ok {
+		e = fmt.Errorf("cherry: no consumer registered for %q", mediaType)
 		return
 	}
 	a = new(A)
-	if err = json.NewDecoder(resp.Body).Decode(a); err != nil {
+	if err = consumer.Consume(resp.Body, a); err != nil {
 		a = nil
 		e = err
 		return
@@ -118,6 +222,24 @@ type Request[A any] struct {
 	Headers map[string]string
 	// Body is request's body.
 	Body any
+	// ContentType is the media type used to encode Body and sent as the
+	// Content-Type header. It defaults to "application/json".
+	ContentType string
+	// Accept is the media type sent as the Accept header and used to pick a
+	// decoder for the response body when the response doesn't carry its own
+	// Content-Type. It defaults to "application/json".
+	Accept string
+	// Interceptors run, in order, against the built *http.Request after it
+	// is constructed but before it is sent, letting callers attach
+	// authentication or other request-scoped behavior.
+	Interceptors []RequestInterceptor
+	// Retry, when set, governs how SendWithContext retries this request
+	// after a retryable failure.
+	Retry *RetryPolicy
+	// ResponseWriter, when set, causes Send to copy the response body into
+	// it instead of decoding it, for binary responses such as images or
+	// archives. The returned *A is nil when ResponseWriter is used.
+	ResponseWriter io.Writer
 }
 
 // Get creates a new GET a return value of type A.
@@ -6,9 +6,9 @@ import (
 	"testing"
 
 	validation "github.com/go-ozzo/ozzo-validation"
-	"github.com/onur1/cherry"
-	"github.com/onur1/middleware"
 	"github.com/stretchr/testify/assert"
+	"github.com/tetsuo/cherry"
+	"github.com/tetsuo/middleware"
 )
 
 type entry struct {
@@ -24,13 +24,13 @@ func (e *entry) Validate() error {
 
 type testClient struct {
 	http.Client
-	w   *httptest.ResponseRecorder
 	mux *http.ServeMux
 }
 
 func (c *testClient) Do(req *http.Request) (*http.Response, error) {
-	c.mux.ServeHTTP(c.w, req)
-	response := c.w.Result()
+	w := httptest.NewRecorder()
+	c.mux.ServeHTTP(w, req)
+	response := w.Result()
 	response.Request = req
 	return response, nil
 }
@@ -104,7 +104,7 @@ func TestCherry(t *testing.T) {
 			middleware: middleware.PlainText("hi"),
 			request:    cherry.Get[entry]("/", nil),
 			assertErr: func(t *testing.T, entry *entry, resp *http.Response, err error) {
-				assert.Equal(t, "invalid character 'h' looking for beginning of value", err.Error())
+				assert.Equal(t, "cherry: text consumer cannot consume into *cherry_test.entry", err.Error())
 				assert.Nil(t, entry)
 				assert.NotNil(t, resp)
 			},
@@ -131,7 +131,6 @@ func TestCherry(t *testing.T) {
 			}))
 
 			resp, a, err := cherry.Send(&testClient{
-				w:   httptest.NewRecorder(),
 				mux: mux,
 			}, tC.request)
 			if tC.assertErr == nil {
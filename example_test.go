@@ -1,6 +1,7 @@
 package cherry_test
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -62,7 +63,7 @@ func ExampleErrBadURL() {
 	resp, _, err := cherry.Send(http.DefaultClient, req)
 	if err != nil {
 		fmt.Println(err.Error())
-		fmt.Println(err == cherry.ErrBadURL)
+		fmt.Println(errors.Is(err, cherry.ErrBadURL))
 	}
 
 	fmt.Println(resp.StatusCode)
@@ -0,0 +1,128 @@
+package cherry
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// gqlRequestBody is the envelope POSTed for a GraphQL operation.
+type gqlRequestBody struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+}
+
+// gqlResponseEnvelope is the standard GraphQL response shape, as described
+// in the GraphQL spec's "Response Format" section.
+type gqlResponseEnvelope[A any] struct {
+	Data   *A         `json:"data"`
+	Errors []gqlError `json:"errors,omitempty"`
+}
+
+type gqlError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// A GraphQLError is a single error reported in a GraphQL response's "errors"
+// array.
+type GraphQLError struct {
+	// Message is the human-readable error description.
+	Message string
+	// Path locates the response field associated with the error.
+	Path []any
+	// Extensions carries any additional, server-defined error information.
+	Extensions map[string]any
+}
+
+// GraphQLErrors is returned by SendGQL when a GraphQL response's "errors"
+// array is non-empty.
+type GraphQLErrors []GraphQLError
+
+// Error joins the message of every reported error.
+func (e GraphQLErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, ge := range e {
+		msgs[i] = ge.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// GQL creates a new GraphQL query request against endpoint, running query
+// with the given variables, and with a return value of type A decoded from
+// the response envelope's "data" field. Send it with SendGQL.
+func GQL[A any](endpoint, query string, variables map[string]any, headers map[string]string) *Request[A] {
+	return &Request[A]{
+		Method:  "POST",
+		URL:     endpoint,
+		Headers: headers,
+		Body: &gqlRequestBody{
+			Query:     query,
+			Variables: variables,
+		},
+	}
+}
+
+// Mutation creates a new GraphQL mutation request against endpoint, running
+// mutation with the given variables, and with a return value of type A
+// decoded from the response envelope's "data" field. Send it with SendGQL.
+func Mutation[A any](endpoint, mutation string, variables map[string]any, headers map[string]string) *Request[A] {
+	return &Request[A]{
+		Method:  "POST",
+		URL:     endpoint,
+		Headers: headers,
+		Body: &gqlRequestBody{
+			Query:     mutation,
+			Variables: variables,
+		},
+	}
+}
+
+// SendGQL sends a GraphQL request built with GQL or Mutation, returning an
+// HTTP response and a pointer to a value of type A decoded from the
+// response envelope's "data" field. If the envelope's "errors" array is
+// non-empty, it returns a GraphQLErrors and a nil *A; the envelope, "data"
+// included, is still decoded first, so a malformed "data" field yields the
+// decode error instead.
+func SendGQL[A any](c Client, r *Request[A]) (resp *http.Response, a *A, e error) {
+	return SendGQLWithContext(context.Background(), c, r)
+}
+
+// SendGQLWithContext is SendGQL with a caller-supplied context.
+func SendGQLWithContext[A any](ctx context.Context, c Client, r *Request[A]) (resp *http.Response, a *A, e error) {
+	resp, env, err := SendWithContext(ctx, c, &Request[gqlResponseEnvelope[A]]{
+		Method:       r.Method,
+		URL:          r.URL,
+		Headers:      r.Headers,
+		Body:         r.Body,
+		ContentType:  r.ContentType,
+		Accept:       r.Accept,
+		Interceptors: r.Interceptors,
+		Retry:        r.Retry,
+	})
+	return sendGQLResult(resp, env, err)
+}
+
+// sendGQLResult unwraps a *Request[gqlResponseEnvelope[A]] response triple
+// into the shape SendGQL promises its callers.
+func sendGQLResult[A any](resp *http.Response, env *gqlResponseEnvelope[A], e error) (*http.Response, *A, error) {
+	if e != nil {
+		return resp, nil, e
+	}
+	if len(env.Errors) > 0 {
+		errs := make(GraphQLErrors, len(env.Errors))
+		for i, ge := range env.Errors {
+			errs[i] = GraphQLError{Message: ge.Message, Path: ge.Path, Extensions: ge.Extensions}
+		}
+		return resp, nil, errs
+	}
+	a := env.Data
+	if validationErrors := validation.Validate(a); validationErrors != nil {
+		return resp, a, validationErrors
+	}
+	return resp, a, nil
+}
@@ -0,0 +1,80 @@
+package cherry_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tetsuo/cherry"
+)
+
+type user struct {
+	Name string `json:"name"`
+}
+
+func gqlHandler(t *testing.T, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var decoded struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&decoded))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestSendGQL(t *testing.T) {
+	t.Run("Data", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.Handle("/", gqlHandler(t, `{"data":{"name":"octocat"}}`))
+
+		resp, u, err := cherry.SendGQL(&testClient{
+			mux: mux,
+		}, cherry.GQL[user](
+			"/graphql",
+			"query($l:String!){user(login:$l){name}}",
+			map[string]any{"l": "octocat"},
+			nil,
+		))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "octocat", u.Name)
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.Handle("/", gqlHandler(t, `{"data":null,"errors":[{"message":"not found","path":["user"]}]}`))
+
+		_, u, err := cherry.SendGQL(&testClient{
+			mux: mux,
+		}, cherry.GQL[user]("/graphql", "query{user{name}}", nil, nil))
+
+		assert.Nil(t, u)
+		assert.Error(t, err)
+
+		gqlErrs, ok := err.(cherry.GraphQLErrors)
+		assert.True(t, ok)
+		assert.Equal(t, "not found", gqlErrs.Error())
+		assert.Equal(t, []any{"user"}, gqlErrs[0].Path)
+	})
+}
+
+func TestMutation(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/", gqlHandler(t, `{"data":{"name":"octocat"}}`))
+
+	_, u, err := cherry.SendGQL(&testClient{
+		mux: mux,
+	}, cherry.Mutation[user](
+		"/graphql",
+		"mutation($n:String!){renameUser(name:$n){name}}",
+		map[string]any{"n": "octocat"},
+		nil,
+	))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "octocat", u.Name)
+}
@@ -0,0 +1,66 @@
+package cherry
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// An HTTPError is returned by Send when a response's status code is not
+// 2xx. It wraps ErrBadStatus (or ErrBadURL for a 404), so existing
+// errors.Is(err, cherry.ErrBadStatus) checks keep working, while also
+// carrying the response's raw body so callers can decode structured error
+// payloads, such as RFC 7807 problem details.
+type HTTPError struct {
+	// StatusCode is the response's HTTP status code.
+	StatusCode int
+	// Status is the response's HTTP status line.
+	Status string
+	// Body is the response's raw, already-consumed body.
+	Body []byte
+
+	err error
+}
+
+func newHTTPError(resp *http.Response) *HTTPError {
+	body, _ := io.ReadAll(resp.Body)
+	wrapped := ErrBadStatus
+	if resp.StatusCode == 404 {
+		wrapped = ErrBadURL
+	}
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       body,
+		err:        wrapped,
+	}
+}
+
+// Error returns the message of the wrapped ErrBadStatus or ErrBadURL.
+func (e *HTTPError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns ErrBadStatus or ErrBadURL, so errors.Is sees through
+// HTTPError.
+func (e *HTTPError) Unwrap() error {
+	return e.err
+}
+
+// Decode JSON-decodes Body into target, e.g. a caller-supplied
+// problem-details struct:
+//
+//	var httpErr *cherry.HTTPError
+//	if errors.As(err, &httpErr) {
+//		var apiErr APIError
+//		if err := httpErr.Decode(&apiErr); err == nil {
+//			...
+//		}
+//	}
+//
+// Named Decode rather than As: a method called As right next to errors.As
+// in the example above reads like it implements the errors.As interface,
+// which it doesn't.
+func (e *HTTPError) Decode(target any) error {
+	return json.Unmarshal(e.Body, target)
+}
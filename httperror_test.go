@@ -0,0 +1,55 @@
+package cherry_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tetsuo/cherry"
+)
+
+type problemDetails struct {
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+func TestHTTPError(t *testing.T) {
+	t.Run("BadStatus", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(400)
+			_, _ = w.Write([]byte(`{"title":"invalid","detail":"the id is missing"}`))
+		})
+
+		_, _, err := cherry.Send(&testClient{mux: mux}, cherry.Get[entry]("/", nil))
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, cherry.ErrBadStatus)
+
+		var httpErr *cherry.HTTPError
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, 400, httpErr.StatusCode)
+
+		var problem problemDetails
+		assert.NoError(t, httpErr.Decode(&problem))
+		assert.Equal(t, "invalid", problem.Title)
+		assert.Equal(t, "the id is missing", problem.Detail)
+	})
+
+	t.Run("BadURL", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(404)
+		})
+
+		_, _, err := cherry.Send(&testClient{mux: mux}, cherry.Get[entry]("/cherry", nil))
+
+		assert.ErrorIs(t, err, cherry.ErrBadURL)
+
+		var httpErr *cherry.HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, 404, httpErr.StatusCode)
+	})
+}
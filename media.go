@@ -0,0 +1,239 @@
+package cherry
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+
+	"github.com/gorilla/schema"
+)
+
+// Well-known media types supported by the default MediaTypeRegistry.
+const (
+	MediaTypeJSON        = "application/json"
+	MediaTypeXML         = "application/xml"
+	MediaTypeForm        = "application/x-www-form-urlencoded"
+	MediaTypeText        = "text/plain"
+	MediaTypeOctetStream = "application/octet-stream"
+)
+
+// A Producer serializes a value and writes it to w.
+type Producer interface {
+	Produce(w io.Writer, v any) error
+}
+
+// A Consumer reads from r and deserializes into v.
+type Consumer interface {
+	Consume(r io.Reader, v any) error
+}
+
+// ProducerFunc adapts a function to a Producer.
+type ProducerFunc func(w io.Writer, v any) error
+
+// Produce calls fn(w, v).
+func (fn ProducerFunc) Produce(w io.Writer, v any) error {
+	return fn(w, v)
+}
+
+// ConsumerFunc adapts a function to a Consumer.
+type ConsumerFunc func(r io.Reader, v any) error
+
+// Consume calls fn(r, v).
+func (fn ConsumerFunc) Consume(r io.Reader, v any) error {
+	return fn(r, v)
+}
+
+// JSONProducer returns a Producer that JSON-encodes v.
+func JSONProducer() Producer {
+	return ProducerFunc(func(w io.Writer, v any) error {
+		return json.NewEncoder(w).Encode(v)
+	})
+}
+
+// JSONConsumer returns a Consumer that JSON-decodes into v.
+func JSONConsumer() Consumer {
+	return ConsumerFunc(func(r io.Reader, v any) error {
+		return json.NewDecoder(r).Decode(v)
+	})
+}
+
+// XMLProducer returns a Producer that XML-encodes v.
+func XMLProducer() Producer {
+	return ProducerFunc(func(w io.Writer, v any) error {
+		return xml.NewEncoder(w).Encode(v)
+	})
+}
+
+// XMLConsumer returns a Consumer that XML-decodes into v.
+func XMLConsumer() Consumer {
+	return ConsumerFunc(func(r io.Reader, v any) error {
+		return xml.NewDecoder(r).Decode(v)
+	})
+}
+
+// FormProducer returns a Producer that encodes v as
+// application/x-www-form-urlencoded, using struct tags understood by
+// gorilla/schema.
+func FormProducer() Producer {
+	encoder := schema.NewEncoder()
+	return ProducerFunc(func(w io.Writer, v any) error {
+		values := url.Values{}
+		if err := encoder.Encode(v, values); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, values.Encode())
+		return err
+	})
+}
+
+// FormConsumer returns a Consumer that decodes
+// application/x-www-form-urlencoded data into v, using struct tags
+// understood by gorilla/schema.
+func FormConsumer() Consumer {
+	decoder := schema.NewDecoder()
+	return ConsumerFunc(func(r io.Reader, v any) error {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		values, err := url.ParseQuery(string(b))
+		if err != nil {
+			return err
+		}
+		return decoder.Decode(v, values)
+	})
+}
+
+// TextProducer returns a Producer that writes v as plain text. v must be a
+// string, a *string or a fmt.Stringer.
+func TextProducer() Producer {
+	return ProducerFunc(func(w io.Writer, v any) error {
+		var s string
+		switch t := v.(type) {
+		case string:
+			s = t
+		case *string:
+			s = *t
+		case fmt.Stringer:
+			s = t.String()
+		default:
+			return fmt.Errorf("cherry: text producer cannot produce %T", v)
+		}
+		_, err := io.WriteString(w, s)
+		return err
+	})
+}
+
+// TextConsumer returns a Consumer that reads plain text into v. v must be a
+// *string.
+func TextConsumer() Consumer {
+	return ConsumerFunc(func(r io.Reader, v any) error {
+		dst, ok := v.(*string)
+		if !ok {
+			return fmt.Errorf("cherry: text consumer cannot consume into %T", v)
+		}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		*dst = string(b)
+		return nil
+	})
+}
+
+// ByteStreamProducer returns a Producer for application/octet-stream that
+// copies from the io.Reader given as v into w.
+func ByteStreamProducer() Producer {
+	return ProducerFunc(func(w io.Writer, v any) error {
+		src, ok := v.(io.Reader)
+		if !ok {
+			return fmt.Errorf("cherry: byte stream producer needs an io.Reader, got %T", v)
+		}
+		_, err := io.Copy(w, src)
+		return err
+	})
+}
+
+// ByteStreamConsumer returns a Consumer for application/octet-stream that
+// copies r into the io.Writer given as v.
+func ByteStreamConsumer() Consumer {
+	return ConsumerFunc(func(r io.Reader, v any) error {
+		dst, ok := v.(io.Writer)
+		if !ok {
+			return fmt.Errorf("cherry: byte stream consumer needs an io.Writer, got %T", v)
+		}
+		_, err := io.Copy(dst, r)
+		return err
+	})
+}
+
+// A MediaTypeRegistry maps MIME types to the Producer or Consumer that knows
+// how to encode or decode that representation, letting Send pick an
+// encoding based on a request's Content-Type and a response's Content-Type
+// (or Accept).
+type MediaTypeRegistry struct {
+	producers map[string]Producer
+	consumers map[string]Consumer
+}
+
+// NewMediaTypeRegistry returns a registry pre-populated with cherry's
+// built-in JSON, XML, form, text and octet-stream support.
+func NewMediaTypeRegistry() *MediaTypeRegistry {
+	m := &MediaTypeRegistry{
+		producers: make(map[string]Producer),
+		consumers: make(map[string]Consumer),
+	}
+	m.RegisterProducer(MediaTypeJSON, JSONProducer())
+	m.RegisterConsumer(MediaTypeJSON, JSONConsumer())
+	m.RegisterProducer(MediaTypeXML, XMLProducer())
+	m.RegisterConsumer(MediaTypeXML, XMLConsumer())
+	m.RegisterProducer(MediaTypeForm, FormProducer())
+	m.RegisterConsumer(MediaTypeForm, FormConsumer())
+	m.RegisterProducer(MediaTypeText, TextProducer())
+	m.RegisterConsumer(MediaTypeText, TextConsumer())
+	m.RegisterProducer(MediaTypeOctetStream, ByteStreamProducer())
+	m.RegisterConsumer(MediaTypeOctetStream, ByteStreamConsumer())
+	return m
+}
+
+// RegisterProducer registers p as the Producer for mediaType.
+func (m *MediaTypeRegistry) RegisterProducer(mediaType string, p Producer) {
+	m.producers[mediaType] = p
+}
+
+// RegisterConsumer registers c as the Consumer for mediaType.
+func (m *MediaTypeRegistry) RegisterConsumer(mediaType string, c Consumer) {
+	m.consumers[mediaType] = c
+}
+
+// ProducerFor returns the Producer registered for mediaType, if any.
+// Parameters such as "; charset=utf-8" are ignored.
+func (m *MediaTypeRegistry) ProducerFor(mediaType string) (Producer, bool) {
+	p, ok := m.producers[normalizeMediaType(mediaType)]
+	return p, ok
+}
+
+// ConsumerFor returns the Consumer registered for mediaType, if any.
+// Parameters such as "; charset=utf-8" are ignored.
+func (m *MediaTypeRegistry) ConsumerFor(mediaType string) (Consumer, bool) {
+	c, ok := m.consumers[normalizeMediaType(mediaType)]
+	return c, ok
+}
+
+func normalizeMediaType(v string) string {
+	if v == "" {
+		return ""
+	}
+	mt, _, err := mime.ParseMediaType(v)
+	if err != nil {
+		return v
+	}
+	return mt
+}
+
+// DefaultMediaTypeRegistry is the MediaTypeRegistry used by Send and
+// SendWithContext.
+var DefaultMediaTypeRegistry = NewMediaTypeRegistry()
@@ -0,0 +1,90 @@
+package cherry_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tetsuo/cherry"
+)
+
+type point struct {
+	X int `json:"x" schema:"x"`
+	Y int `json:"y" schema:"y"`
+}
+
+func TestMediaTypeRegistry(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		mediaType string
+		produce   any
+		consume   any
+		expected  any
+	}{
+		{
+			desc:      "JSON",
+			mediaType: cherry.MediaTypeJSON,
+			produce:   &point{X: 1, Y: 2},
+			consume:   &point{},
+			expected:  &point{X: 1, Y: 2},
+		},
+		{
+			desc:      "XML",
+			mediaType: cherry.MediaTypeXML,
+			produce:   &point{X: 1, Y: 2},
+			consume:   &point{},
+			expected:  &point{X: 1, Y: 2},
+		},
+		{
+			desc:      "Form",
+			mediaType: cherry.MediaTypeForm,
+			produce:   &point{X: 1, Y: 2},
+			consume:   &point{},
+			expected:  &point{X: 1, Y: 2},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			producer, ok := cherry.DefaultMediaTypeRegistry.ProducerFor(tC.mediaType + "; charset=utf-8")
+			assert.True(t, ok)
+
+			var buf bytes.Buffer
+			assert.NoError(t, producer.Produce(&buf, tC.produce))
+
+			consumer, ok := cherry.DefaultMediaTypeRegistry.ConsumerFor(tC.mediaType)
+			assert.True(t, ok)
+
+			assert.NoError(t, consumer.Consume(&buf, tC.consume))
+			assert.Equal(t, tC.expected, tC.consume)
+		})
+	}
+}
+
+func TestTextProducerConsumer(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, cherry.TextProducer().Produce(&buf, "hello"))
+	assert.Equal(t, "hello", buf.String())
+
+	var out string
+	assert.NoError(t, cherry.TextConsumer().Consume(strings.NewReader("world"), &out))
+	assert.Equal(t, "world", out)
+}
+
+func TestByteStreamProducerConsumer(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, cherry.ByteStreamProducer().Produce(&buf, strings.NewReader("binary")))
+	assert.Equal(t, "binary", buf.String())
+
+	var out bytes.Buffer
+	assert.NoError(t, cherry.ByteStreamConsumer().Consume(strings.NewReader("data"), &out))
+	assert.Equal(t, "data", out.String())
+}
+
+func TestMediaTypeRegistryUnknown(t *testing.T) {
+	_, ok := cherry.DefaultMediaTypeRegistry.ProducerFor("application/unknown")
+	assert.False(t, ok)
+
+	_, ok = cherry.DefaultMediaTypeRegistry.ConsumerFor("application/unknown")
+	assert.False(t, ok)
+}
@@ -0,0 +1,103 @@
+package cherry
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// A RetryPolicy controls how SendWithContext retries a request after a
+// retryable failure: a non-2xx status listed in RetryableStatuses, or,
+// when RetryOnNetworkError is set, a transport-level error.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// A value of zero or one disables retrying.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay.
+	MaxDelay time.Duration
+	// Multiplier scales BaseDelay after each attempt. It defaults to 2 when
+	// left at zero.
+	Multiplier float64
+	// Jitter, when true, applies full jitter to the computed delay.
+	Jitter bool
+	// RetryableStatuses lists the HTTP status codes that should trigger a
+	// retry.
+	RetryableStatuses []int
+	// RetryOnNetworkError retries on transport-level errors, such as
+	// timeouts or connection resets, in addition to RetryableStatuses.
+	RetryOnNetworkError bool
+}
+
+// shouldRetry reports whether a failed attempt that produced resp and err
+// should be retried under p. A resp of nil with an ErrBadRequest means the
+// request itself could never be sent, which is never retryable.
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if errors.Is(err, ErrBadRequest) {
+		return false
+	}
+	if resp == nil {
+		return p.RetryOnNetworkError
+	}
+	for _, status := range p.RetryableStatuses {
+		if status == resp.StatusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// delay computes how long to wait before the next attempt, honoring a
+// Retry-After header on resp when present and otherwise falling back to
+// exponential backoff.
+func (p *RetryPolicy) delay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	return p.backoff(attempt)
+}
+
+// backoff computes the exponential backoff delay for attempt, capped at
+// MaxDelay and, when Jitter is set, scaled by full jitter.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	d := float64(p.BaseDelay) * math.Pow(multiplier, float64(attempt))
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	delay := time.Duration(d)
+	if p.Jitter && delay > 0 {
+		delay = time.Duration(rand.Int63n(int64(delay)))
+	}
+	return delay
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a
+// number of seconds or an HTTP-date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,264 @@
+package cherry_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tetsuo/cherry"
+)
+
+// onceReader is an io.Reader with no Seek method, so attempting to retry a
+// Stream request built from it must fail rather than silently resend an
+// empty body.
+type onceReader struct {
+	r io.Reader
+}
+
+func (o *onceReader) Read(b []byte) (int, error) {
+	return o.r.Read(b)
+}
+
+func TestSendWithOptionsRetry(t *testing.T) {
+	t.Run("RetriesUntilSuccess", func(t *testing.T) {
+		var attempts int
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				w.WriteHeader(503)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"ab"}`))
+		})
+
+		resp, e, err := cherry.SendWithOptions(
+			context.Background(),
+			&testClient{mux: mux},
+			cherry.Get[entry]("/", nil),
+			&cherry.RetryPolicy{
+				MaxAttempts:       5,
+				BaseDelay:         time.Millisecond,
+				RetryableStatuses: []int{503},
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, "ab", e.ID)
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		var attempts int
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(503)
+		})
+
+		_, _, err := cherry.SendWithOptions(
+			context.Background(),
+			&testClient{mux: mux},
+			cherry.Get[entry]("/", nil),
+			&cherry.RetryPolicy{
+				MaxAttempts:       3,
+				BaseDelay:         time.Millisecond,
+				RetryableStatuses: []int{503},
+			},
+		)
+
+		assert.ErrorIs(t, err, cherry.ErrBadStatus)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("DoesNotRetryNonRetryableStatus", func(t *testing.T) {
+		var attempts int
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(400)
+		})
+
+		_, _, err := cherry.SendWithOptions(
+			context.Background(),
+			&testClient{mux: mux},
+			cherry.Get[entry]("/", nil),
+			&cherry.RetryPolicy{
+				MaxAttempts:       3,
+				BaseDelay:         time.Millisecond,
+				RetryableStatuses: []int{503},
+			},
+		)
+
+		assert.ErrorIs(t, err, cherry.ErrBadStatus)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("HonorsRetryAfterSeconds", func(t *testing.T) {
+		var attempts int
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 2 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(503)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"ab"}`))
+		})
+
+		start := time.Now()
+		_, e, err := cherry.SendWithOptions(
+			context.Background(),
+			&testClient{mux: mux},
+			cherry.Get[entry]("/", nil),
+			&cherry.RetryPolicy{
+				MaxAttempts:       3,
+				BaseDelay:         time.Hour,
+				RetryableStatuses: []int{503},
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ab", e.ID)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("StopsOnContextCancellation", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(503)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, err := cherry.SendWithOptions(
+			ctx,
+			&testClient{mux: mux},
+			cherry.Get[entry]("/", nil),
+			&cherry.RetryPolicy{
+				MaxAttempts:       5,
+				BaseDelay:         time.Hour,
+				RetryableStatuses: []int{503},
+			},
+		)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestRequestRetryField(t *testing.T) {
+	var attempts int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"ab"}`))
+	})
+
+	req := cherry.Get[entry]("/", nil)
+	req.Retry = &cherry.RetryPolicy{
+		MaxAttempts:       3,
+		BaseDelay:         time.Millisecond,
+		RetryableStatuses: []int{503},
+	}
+
+	_, e, err := cherry.Send(&testClient{mux: mux}, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ab", e.ID)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryWithStreamBody(t *testing.T) {
+	t.Run("RewindsSeekableBody", func(t *testing.T) {
+		var (
+			attempts int
+			gotBody  string
+		)
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			b, _ := io.ReadAll(r.Body)
+			if attempts < 2 {
+				w.WriteHeader(503)
+				return
+			}
+			gotBody = string(b)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"ab"}`))
+		})
+
+		req := cherry.Stream[entry]("POST", "/", bytes.NewReader([]byte("payload-data")), "application/octet-stream", nil)
+		req.Retry = &cherry.RetryPolicy{
+			MaxAttempts:       3,
+			BaseDelay:         time.Millisecond,
+			RetryableStatuses: []int{503},
+		}
+
+		_, e, err := cherry.Send(&testClient{mux: mux}, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ab", e.ID)
+		assert.Equal(t, 2, attempts)
+		assert.Equal(t, "payload-data", gotBody)
+	})
+
+	t.Run("RejectsNonSeekableBody", func(t *testing.T) {
+		var attempts int
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(503)
+		})
+
+		req := cherry.Stream[entry]("POST", "/", &onceReader{r: bytes.NewReader([]byte("payload-data"))}, "application/octet-stream", nil)
+		req.Retry = &cherry.RetryPolicy{
+			MaxAttempts:       3,
+			BaseDelay:         time.Millisecond,
+			RetryableStatuses: []int{503},
+		}
+
+		_, _, err := cherry.Send(&testClient{mux: mux}, req)
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, cherry.ErrBadRequest)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("RejectsMultipartBody", func(t *testing.T) {
+		var attempts int
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(503)
+		})
+
+		req := cherry.Multipart[entry]("/", []cherry.MultipartPart{
+			{Name: "title", Content: bytes.NewReader([]byte("hello"))},
+		}, nil)
+		req.Retry = &cherry.RetryPolicy{
+			MaxAttempts:       3,
+			BaseDelay:         time.Millisecond,
+			RetryableStatuses: []int{503},
+		}
+
+		_, _, err := cherry.Send(&testClient{mux: mux}, req)
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, cherry.ErrBadRequest)
+		assert.Equal(t, 1, attempts)
+	})
+}
@@ -0,0 +1,98 @@
+package cherry
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+)
+
+// rawBody marks a Request's Body as having been built by Stream or
+// Multipart, so toRequestWithContext sends it as-is instead of passing it
+// to a Producer. Wrapping it in a dedicated type, rather than matching on
+// io.Reader directly, keeps an ordinary payload struct that happens to
+// implement Read from being mistaken for one.
+type rawBody struct {
+	io.Reader
+}
+
+// Stream creates a new request whose body is sent directly from body,
+// bypassing the Producer that would otherwise marshal Body into memory.
+// This suits large payloads, such as file uploads, that shouldn't be
+// buffered whole. If a RetryPolicy is attached and a retry is attempted,
+// body must implement io.Seeker so it can be rewound; otherwise the retry
+// fails with ErrBadRequest instead of silently resending an empty body.
+func Stream[A any](method, url string, body io.Reader, contentType string, headers map[string]string) *Request[A] {
+	return &Request[A]{
+		Method:      method,
+		URL:         url,
+		Headers:     headers,
+		Body:        rawBody{body},
+		ContentType: contentType,
+	}
+}
+
+// A MultipartPart is a single part of a multipart/form-data request built
+// by Multipart. It is a plain form field when Filename is empty, or a file
+// upload otherwise.
+type MultipartPart struct {
+	// Name is the part's form field name.
+	Name string
+	// Filename, when non-empty, marks this part as a file upload.
+	Filename string
+	// Content is the part's body.
+	Content io.Reader
+	// ContentType is the file part's Content-Type. It's ignored for plain
+	// form fields, and defaults to "application/octet-stream" when empty.
+	ContentType string
+}
+
+// Multipart creates a new POST request with a multipart/form-data body
+// built from parts. The body is streamed through an io.Pipe as it's sent,
+// so file parts are never buffered in memory. Because an io.Pipe can only
+// be read once, this request can't be retried: attaching a RetryPolicy
+// that attempts a retry fails with ErrBadRequest rather than resending an
+// empty body.
+func Multipart[A any](url string, parts []MultipartPart, headers map[string]string) *Request[A] {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeMultipartParts(mw, parts))
+	}()
+
+	return &Request[A]{
+		Method:      "POST",
+		URL:         url,
+		Headers:     headers,
+		Body:        rawBody{pr},
+		ContentType: mw.FormDataContentType(),
+	}
+}
+
+func writeMultipartParts(mw *multipart.Writer, parts []MultipartPart) error {
+	for _, part := range parts {
+		w, err := createMultipartPart(mw, part)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(w, part.Content); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+func createMultipartPart(mw *multipart.Writer, part MultipartPart) (io.Writer, error) {
+	if part.Filename == "" {
+		return mw.CreateFormField(part.Name)
+	}
+	contentType := part.ContentType
+	if contentType == "" {
+		contentType = MediaTypeOctetStream
+	}
+	return mw.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {fmt.Sprintf("form-data; name=%q; filename=%q", part.Name, part.Filename)},
+		"Content-Type":        {contentType},
+	})
+}
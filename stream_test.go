@@ -0,0 +1,176 @@
+package cherry_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tetsuo/cherry"
+)
+
+func TestStream(t *testing.T) {
+	mux := http.NewServeMux()
+	var (
+		gotContentType string
+		gotBody        string
+	)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"ab"}`))
+	})
+
+	_, e, err := cherry.Send(&testClient{mux: mux}, cherry.Stream[entry](
+		"POST", "/", strings.NewReader("raw payload"), "application/octet-stream", nil,
+	))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ab", e.ID)
+	assert.Equal(t, "application/octet-stream", gotContentType)
+	assert.Equal(t, "raw payload", gotBody)
+}
+
+func TestMultipart(t *testing.T) {
+	mux := http.NewServeMux()
+	var (
+		gotField string
+		gotFile  string
+		gotName  string
+	)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		assert.NoError(t, err)
+
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			b, _ := io.ReadAll(p)
+			switch p.FormName() {
+			case "title":
+				gotField = string(b)
+			case "file":
+				gotFile = string(b)
+				gotName = p.FileName()
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"ab"}`))
+	})
+
+	_, e, err := cherry.Send(&testClient{mux: mux}, cherry.Multipart[entry]("/", []cherry.MultipartPart{
+		{Name: "title", Content: strings.NewReader("hello")},
+		{Name: "file", Filename: "a.txt", Content: strings.NewReader("file contents"), ContentType: "text/plain"},
+	}, nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ab", e.ID)
+	assert.Equal(t, "hello", gotField)
+	assert.Equal(t, "file contents", gotFile)
+	assert.Equal(t, "a.txt", gotName)
+}
+
+// weirdPayload happens to implement io.Reader for unrelated reasons (e.g.
+// exposing its own content as a stream). Posting it must still JSON-encode
+// it via a Producer rather than treating it as a raw Stream/Multipart body.
+type weirdPayload struct {
+	Name string `json:"name"`
+}
+
+func (p *weirdPayload) Read(b []byte) (int, error) {
+	return copy(b, p.Name), nil
+}
+
+func TestPostWithReaderShapedPayload(t *testing.T) {
+	mux := http.NewServeMux()
+	var gotBody string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"ab"}`))
+	})
+
+	_, e, err := cherry.Send(&testClient{mux: mux}, cherry.Post[entry](
+		"/", &weirdPayload{Name: "alice"}, nil,
+	))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ab", e.ID)
+	assert.Equal(t, `{"name":"alice"}`+"\n", gotBody)
+}
+
+// TestMultipartGoroutineLeak guards against a leaked writer goroutine when
+// a Multipart request is abandoned before it reaches Client.Do: the body is
+// an io.Pipe, and nobody would otherwise ever read or close its reader end.
+func TestMultipartGoroutineLeak(t *testing.T) {
+	newReq := func() *cherry.Request[entry] {
+		return cherry.Multipart[entry]("/", []cherry.MultipartPart{
+			{Name: "title", Content: strings.NewReader("hello")},
+		}, nil)
+	}
+
+	baseline := goroutineCount(t)
+
+	t.Run("BadURL", func(t *testing.T) {
+		req := newReq()
+		req.URL = "http://example.com/\x7f"
+
+		_, _, err := cherry.Send(&testClient{mux: http.NewServeMux()}, req)
+		assert.ErrorIs(t, err, cherry.ErrBadRequest)
+	})
+
+	t.Run("InterceptorError", func(t *testing.T) {
+		boom := errors.New("boom")
+		req := newReq()
+		req.Interceptors = []cherry.RequestInterceptor{
+			cherry.RequestInterceptorFunc(func(*http.Request) error { return boom }),
+		}
+
+		_, _, err := cherry.Send(&testClient{mux: http.NewServeMux()}, req)
+		assert.ErrorIs(t, err, cherry.ErrBadRequest)
+	})
+
+	assert.Equal(t, baseline, goroutineCount(t))
+}
+
+// goroutineCount returns the current goroutine count after letting any
+// abandoned goroutines unwind.
+func goroutineCount(t *testing.T) int {
+	t.Helper()
+	runtime.GC()
+	time.Sleep(50 * time.Millisecond)
+	return runtime.NumGoroutine()
+}
+
+func TestResponseWriter(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("binary data"))
+	})
+
+	var sink bytes.Buffer
+	_, e, err := cherry.Send(&testClient{mux: mux}, &cherry.Request[entry]{
+		Method:         "GET",
+		URL:            "/",
+		ResponseWriter: &sink,
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+	assert.Equal(t, "binary data", sink.String())
+}